@@ -0,0 +1,165 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DropPolicy controls what AsyncHandler does when its buffer is full.
+type DropPolicy int
+
+const (
+	// DropPolicyBlock blocks the caller until there is room in the buffer.
+	DropPolicyBlock DropPolicy = iota
+	// DropPolicyDropOldest discards the oldest buffered record to make room.
+	DropPolicyDropOldest
+	// DropPolicyDropNewest discards the incoming record.
+	DropPolicyDropNewest
+)
+
+var (
+	asyncQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "log_async_queue_depth",
+		Help: "Number of records currently buffered by an async log handler.",
+	})
+	asyncDropsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "log_async_drops_total",
+		Help: "Total number of records dropped by an async log handler.",
+	})
+	asyncFlushSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "log_async_flush_seconds",
+		Help:    "Duration of Flush calls on an async log handler.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// AsyncCollectors returns the Prometheus collectors shared by all AsyncHandlers,
+// for registration.
+func AsyncCollectors() []prometheus.Collector {
+	return []prometheus.Collector{asyncQueueDepth, asyncDropsTotal, asyncFlushSeconds}
+}
+
+// asyncRecord pairs a buffered record with the handler (with its own
+// WithAttrs/WithGroup state) that must format and emit it, so records logged
+// through a With()-derived asyncHandler are handled by the right next, not
+// the original handler's.
+type asyncRecord struct {
+	next   slog.Handler
+	record slog.Record
+}
+
+// asyncHandler decouples record formatting/IO from the caller via a bounded
+// channel drained by a background goroutine.
+type asyncHandler struct {
+	next   slog.Handler
+	policy DropPolicy
+	onDrop func(slog.Record)
+
+	records chan asyncRecord
+	done    chan struct{}
+}
+
+// NewAsyncHandler wraps next so that Handle enqueues the record into a
+// buffer of bufSize and returns immediately; a background goroutine drains
+// the buffer into next. When the buffer is full, policy decides whether the
+// caller blocks or the oldest/newest record is dropped; onDrop, if non-nil,
+// is called with every dropped record.
+func NewAsyncHandler(next slog.Handler, bufSize int, policy DropPolicy, onDrop func(slog.Record)) slog.Handler {
+	h := &asyncHandler{
+		next:    next,
+		policy:  policy,
+		onDrop:  onDrop,
+		records: make(chan asyncRecord, bufSize),
+		done:    make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+func (h *asyncHandler) run() {
+	defer close(h.done)
+	for item := range h.records {
+		asyncQueueDepth.Set(float64(len(h.records)))
+		_ = item.next.Handle(context.Background(), item.record)
+	}
+}
+
+func (h *asyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *asyncHandler) Handle(_ context.Context, record slog.Record) error {
+	item := asyncRecord{next: h.next, record: record}
+
+	switch h.policy {
+	case DropPolicyBlock:
+		h.records <- item
+	case DropPolicyDropNewest:
+		select {
+		case h.records <- item:
+		default:
+			h.drop(record)
+		}
+	case DropPolicyDropOldest:
+		for {
+			select {
+			case h.records <- item:
+				return nil
+			default:
+			}
+			select {
+			case oldest := <-h.records:
+				h.drop(oldest.record)
+			default:
+			}
+		}
+	}
+
+	asyncQueueDepth.Set(float64(len(h.records)))
+	return nil
+}
+
+func (h *asyncHandler) drop(record slog.Record) {
+	asyncDropsTotal.Inc()
+	if h.onDrop != nil {
+		h.onDrop(record)
+	}
+}
+
+func (h *asyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &asyncHandler{next: h.next.WithAttrs(attrs), policy: h.policy, onDrop: h.onDrop, records: h.records, done: h.done}
+}
+
+func (h *asyncHandler) WithGroup(name string) slog.Handler {
+	return &asyncHandler{next: h.next.WithGroup(name), policy: h.policy, onDrop: h.onDrop, records: h.records, done: h.done}
+}
+
+// Flush closes the buffer and blocks until every record buffered so far has
+// been handled by next and the background goroutine has exited, or ctx is
+// done. Handle must not be called on h, or on any handler derived from it,
+// after Flush.
+func (h *asyncHandler) Flush(ctx context.Context) error {
+	start := time.Now()
+	defer func() { asyncFlushSeconds.Observe(time.Since(start).Seconds()) }()
+
+	close(h.records)
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Flush drains and closes an AsyncHandler's buffer, waiting for the
+// background goroutine to finish handling every enqueued record. It is a
+// no-op (returning nil) if handler isn't an AsyncHandler.
+func Flush(ctx context.Context, handler slog.Handler) error {
+	if h, ok := handler.(*asyncHandler); ok {
+		return h.Flush(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAsyncHandlerWithAttrsReachNext(t *testing.T) {
+	var buf syncBuffer
+	h := NewAsyncHandler(slog.NewTextHandler(&buf, nil), 16, DropPolicyBlock, nil)
+	logger := slog.New(h)
+
+	logger.With("reqID", "abc123").Info("handled request")
+
+	if err := Flush(context.Background(), h); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "reqID=abc123") {
+		t.Errorf("expected attrs added via With() to reach next, got: %s", out)
+	}
+}
+
+func TestAsyncHandlerWithGroupReachesNext(t *testing.T) {
+	var buf syncBuffer
+	h := NewAsyncHandler(slog.NewJSONHandler(&buf, nil), 16, DropPolicyBlock, nil)
+	logger := slog.New(h)
+
+	logger.WithGroup("req").With("id", 1).Info("handled request")
+
+	if err := Flush(context.Background(), h); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"req":{"id":1}`) {
+		t.Errorf("expected grouped attrs added via WithGroup()/With() to reach next, got: %s", out)
+	}
+}
+
+func TestAsyncHandlerDropOldest(t *testing.T) {
+	block := make(chan struct{})
+	blocking := blockingHandler{started: make(chan struct{}, 1), block: block}
+
+	h := NewAsyncHandler(blocking, 1, DropPolicyDropOldest, nil)
+	logger := slog.New(h)
+
+	logger.Info("first")
+	<-blocking.started // wait for the background goroutine to pick up "first" and block on it
+
+	logger.Info("second") // fills the size-1 buffer
+	logger.Info("third")  // must drop "second", not block
+
+	close(block)
+	if err := Flush(context.Background(), h); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+}
+
+type blockingHandler struct {
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (h blockingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h blockingHandler) Handle(context.Context, slog.Record) error {
+	select {
+	case h.started <- struct{}{}:
+	default:
+	}
+	<-h.block
+	return nil
+}
+
+func (h blockingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h blockingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestAsyncHandlerFlushTimesOut(t *testing.T) {
+	block := make(chan struct{})
+	h := NewAsyncHandler(blockingHandler{started: make(chan struct{}, 1), block: block}, 4, DropPolicyBlock, nil)
+	logger := slog.New(h)
+	logger.Info("stuck")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := Flush(ctx, h); err == nil {
+		t.Error("Flush() = nil, want a context deadline error while the handler is still blocked")
+	}
+	close(block)
+}
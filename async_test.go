@@ -0,0 +1,58 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// slowHandler simulates a handler whose IO (network syslog, disk fsync, ...)
+// costs more than in-memory formatting, so callers pay for it synchronously
+// unless they go through AsyncHandler.
+type slowHandler struct {
+	cost time.Duration
+}
+
+func (h slowHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h slowHandler) Handle(context.Context, slog.Record) error {
+	time.Sleep(h.cost)
+	return nil
+}
+
+func (h slowHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h slowHandler) WithGroup(string) slog.Handler      { return h }
+
+// BenchmarkAsyncVsSync compares logging through a slow handler directly
+// (as the HTTP middleware and go-pg QueryLogger do today) against going
+// through NewAsyncHandler, under concurrent load.
+func BenchmarkAsyncVsSync(b *testing.B) {
+	record := func() slog.Record {
+		return slog.NewRecord(time.Unix(0, 0), slog.LevelInfo, "HTTP request", 0)
+	}
+
+	b.Run("sync", func(b *testing.B) {
+		h := slowHandler{cost: 50 * time.Microsecond}
+		ctx := context.Background()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = h.Handle(ctx, record())
+			}
+		})
+	})
+
+	b.Run("async", func(b *testing.B) {
+		h := NewAsyncHandler(slowHandler{cost: 50 * time.Microsecond}, 1024, DropPolicyBlock, nil)
+		ctx := context.Background()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = h.Handle(ctx, record())
+			}
+		})
+		b.StopTimer()
+		_ = Flush(context.Background(), h)
+	})
+}
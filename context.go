@@ -0,0 +1,19 @@
+package embedlog
+
+import "context"
+
+// loggerCtxKey is the context key under which a request/call-scoped Logger is stored.
+type loggerCtxKey struct{}
+
+// NewContext returns a copy of ctx carrying logger, retrievable via FromContext.
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext returns the Logger stored in ctx by NewContext, or def if none was stored.
+func FromContext(ctx context.Context, def Logger) Logger {
+	if logger, ok := ctx.Value(loggerCtxKey{}).(Logger); ok {
+		return logger
+	}
+	return def
+}
@@ -0,0 +1,169 @@
+package embedlog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// dedupHandler suppresses repeated records within a sliding window, emitting
+// the first max occurrences of each fingerprint verbatim and a single summary
+// record with a "suppressed" attribute once the window closes. The
+// fingerprint table is held in shared, a pointer common to every handler
+// derived via WithAttrs/WithGroup, so per-request child loggers (the common
+// case via logger.With(...)) still dedupe against each other instead of each
+// starting from a blank slate.
+type dedupHandler struct {
+	next   slog.Handler
+	window time.Duration
+	max    int
+
+	shared *dedupShared
+}
+
+type dedupShared struct {
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	count      int
+	windowEnds time.Time
+	record     slog.Record
+	next       slog.Handler // the handler (with its own WithAttrs/WithGroup state) that logged this fingerprint first
+	timer      *time.Timer
+}
+
+// NewDedupHandler wraps next so that records sharing the same level, message
+// and caller within window are emitted at most max times verbatim; further
+// occurrences are counted and flushed as one summary record with a
+// "suppressed" attribute, either when the next occurrence arrives after the
+// window closes or, if the flood simply stops, when the window's timer fires
+// on its own.
+func NewDedupHandler(next slog.Handler, window time.Duration, max int) slog.Handler {
+	return &dedupHandler{
+		next:   next,
+		window: window,
+		max:    max,
+		shared: &dedupShared{entries: make(map[string]*dedupEntry)},
+	}
+}
+
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *dedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	fingerprint := fmt.Sprintf("%d|%s|%d", record.Level, record.Message, record.PC)
+
+	h.shared.mu.Lock()
+	now := time.Now()
+	entry, ok := h.shared.entries[fingerprint]
+
+	var expired *dedupEntry
+	if ok && now.After(entry.windowEnds) {
+		entry.timer.Stop()
+		delete(h.shared.entries, fingerprint)
+		expired, ok = entry, false
+	}
+	if !ok {
+		entry = &dedupEntry{windowEnds: now.Add(h.window), record: record, next: h.next}
+		entry.timer = time.AfterFunc(h.window, func() { h.expire(fingerprint, entry) })
+		h.shared.entries[fingerprint] = entry
+	}
+	entry.count++
+	passThrough := entry.count <= h.max
+	next := entry.next
+	h.shared.mu.Unlock()
+
+	if expired != nil && expired.count > h.max {
+		h.emitSummary(ctx, expired)
+	}
+
+	if passThrough {
+		return next.Handle(ctx, record)
+	}
+	return nil
+}
+
+// expire flushes entry once its window closes on its own, i.e. when the
+// flood simply stops and no later occurrence ever arrives to trigger the
+// expired-on-next-occurrence path in Handle.
+func (h *dedupHandler) expire(fingerprint string, entry *dedupEntry) {
+	h.shared.mu.Lock()
+	current, ok := h.shared.entries[fingerprint]
+	if !ok || current != entry {
+		h.shared.mu.Unlock()
+		return
+	}
+	delete(h.shared.entries, fingerprint)
+	h.shared.mu.Unlock()
+
+	if entry.count > h.max {
+		h.emitSummary(context.Background(), entry)
+	}
+}
+
+// emitSummary logs entry's suppressed-count summary through entry.next,
+// i.e. the handler instance (with its own attrs/groups) that first observed
+// this fingerprint.
+func (h *dedupHandler) emitSummary(ctx context.Context, entry *dedupEntry) {
+	summary := entry.record.Clone()
+	summary.AddAttrs(slog.Int("suppressed", entry.count-h.max))
+	_ = entry.next.Handle(ctx, summary)
+}
+
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupHandler{next: h.next.WithAttrs(attrs), window: h.window, max: h.max, shared: h.shared}
+}
+
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	return &dedupHandler{next: h.next.WithGroup(name), window: h.window, max: h.max, shared: h.shared}
+}
+
+// sampledHandler logs one in n records at or above the configured level.
+type sampledHandler struct {
+	next  slog.Handler
+	n     uint64
+	level slog.Level
+
+	counter *uint64
+	mu      *sync.Mutex
+}
+
+// NewSampledHandler wraps next so only one in n records at level (and above)
+// are passed through; records below level are always passed through unchanged.
+// n<=1 disables sampling.
+func NewSampledHandler(next slog.Handler, level slog.Level, n uint64) slog.Handler {
+	return &sampledHandler{next: next, n: n, level: level, counter: new(uint64), mu: new(sync.Mutex)}
+}
+
+func (h *sampledHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *sampledHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < h.level || h.n <= 1 {
+		return h.next.Handle(ctx, record)
+	}
+
+	h.mu.Lock()
+	*h.counter++
+	hit := *h.counter%h.n == 0
+	h.mu.Unlock()
+
+	if !hit {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *sampledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampledHandler{next: h.next.WithAttrs(attrs), n: h.n, level: h.level, counter: h.counter, mu: h.mu}
+}
+
+func (h *sampledHandler) WithGroup(name string) slog.Handler {
+	return &sampledHandler{next: h.next.WithGroup(name), n: h.n, level: h.level, counter: h.counter, mu: h.mu}
+}
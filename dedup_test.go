@@ -0,0 +1,100 @@
+package embedlog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer guards a bytes.Buffer so tests can safely read it while the
+// dedup handler's background expiry timer may still be writing to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDedupHandlerSuppressesWithinWindow(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), time.Minute, 2)
+	logger := slog.New(h)
+
+	for i := 0; i < 5; i++ {
+		logger.Info("flood")
+	}
+
+	out := buf.String()
+	if got, want := strings.Count(out, "msg=flood"), 2; got != want {
+		t.Errorf("logged %d occurrences, want %d (max)", got, want)
+	}
+}
+
+func TestDedupHandlerWithAttrsSharesState(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), time.Minute, 1)
+	logger := slog.New(h)
+
+	// Per-request child loggers (the idiomatic logger.With(...) pattern) must
+	// dedupe against each other, not each start with a fresh table.
+	for i := 0; i < 5; i++ {
+		logger.With("reqID", i).Info("flood")
+	}
+
+	out := buf.String()
+	if got, want := strings.Count(out, "msg=flood"), 1; got != want {
+		t.Errorf("logged %d occurrences across With()-derived loggers, want %d", got, want)
+	}
+	if !strings.Contains(out, "reqID=0") {
+		t.Errorf("expected the surviving line to carry the first child's attrs, got: %s", out)
+	}
+}
+
+func TestDedupHandlerFlushesOnNextOccurrence(t *testing.T) {
+	var buf syncBuffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), 20*time.Millisecond, 1)
+	logger := slog.New(h)
+
+	for i := 0; i < 5; i++ {
+		logger.Info("flood")
+	}
+	time.Sleep(30 * time.Millisecond)
+	logger.Info("flood")
+
+	out := buf.String()
+	if !strings.Contains(out, "suppressed=4") {
+		t.Errorf("expected a suppressed=4 summary once the window closed, got: %s", out)
+	}
+}
+
+func TestDedupHandlerFlushesWhenFloodStops(t *testing.T) {
+	var buf syncBuffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), 20*time.Millisecond, 1)
+	logger := slog.New(h)
+
+	for i := 0; i < 5; i++ {
+		logger.Info("flood")
+	}
+
+	// No further occurrence ever arrives: the summary must still flush once
+	// the window's own timer fires, not only on the next occurrence.
+	time.Sleep(50 * time.Millisecond)
+
+	out := buf.String()
+	if !strings.Contains(out, "suppressed=4") {
+		t.Errorf("expected a suppressed=4 summary after the window elapsed with no further calls, got: %s", out)
+	}
+}
@@ -0,0 +1,130 @@
+package embedlog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// RotationPolicy controls when NewFileLogger rotates its output file.
+type RotationPolicy struct {
+	// MaxSize rotates the file before a write that would take it past MaxSize bytes. 0 disables size-based rotation.
+	MaxSize int64
+	// MaxAge rotates the file once it has been open longer than MaxAge. 0 disables time-based rotation.
+	MaxAge time.Duration
+	// Gzip compresses rotated segments.
+	Gzip bool
+}
+
+// rotatingWriter is an io.Writer that rotates the underlying file according to policy.
+type rotatingWriter struct {
+	mu       sync.Mutex
+	path     string
+	policy   RotationPolicy
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewFileLogger returns a Logger that writes JSON records to path, rotating
+// it according to policy.
+func NewFileLogger(path string, policy RotationPolicy) (Logger, error) {
+	w := &rotatingWriter{path: path, policy: policy}
+	if err := w.open(); err != nil {
+		return Logger{}, err
+	}
+
+	return NewHandlerLogger(slog.NewJSONHandler(w, nil)), nil
+}
+
+func (w *rotatingWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) shouldRotate(next int) bool {
+	if w.policy.MaxSize > 0 && w.size+int64(next) > w.policy.MaxSize {
+		return true
+	}
+	if w.policy.MaxAge > 0 && time.Since(w.openedAt) > w.policy.MaxAge {
+		return true
+	}
+	return false
+}
+
+// rotate closes the current file, renames it with a timestamp suffix
+// (optionally gzip-compressing it), and opens a fresh file at path.
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close rotated log file: %w", err)
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", w.path, time.Now().Format("20060102T150405.000000000"))
+	if err := os.Rename(w.path, rotatedPath); err != nil {
+		return fmt.Errorf("rename rotated log file: %w", err)
+	}
+
+	if w.policy.Gzip {
+		if err := gzipFile(rotatedPath); err != nil {
+			return fmt.Errorf("gzip rotated log file: %w", err)
+		}
+	}
+
+	return w.open()
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,47 @@
+package embedlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFileLoggerWritesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := NewFileLogger(path, RotationPolicy{})
+	if err != nil {
+		t.Fatalf("NewFileLogger() = %v", err)
+	}
+	logger.Print(context.Background(), "hello")
+
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() = %v", err)
+	}
+	if got := string(out); !strings.Contains(got, `"msg":"hello"`) {
+		t.Errorf("log file = %q, want it to contain msg=hello", got)
+	}
+}
+
+func TestNewFileLoggerRotatesOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := NewFileLogger(path, RotationPolicy{MaxSize: 1})
+	if err != nil {
+		t.Fatalf("NewFileLogger() = %v", err)
+	}
+
+	logger.Print(context.Background(), "first")
+	logger.Print(context.Background(), "second")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("ReadDir() = %v", err)
+	}
+	if len(entries) < 2 {
+		t.Errorf("expected a rotated file alongside %q, got entries: %v", path, entries)
+	}
+}
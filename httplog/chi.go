@@ -0,0 +1,21 @@
+package httplog
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vmkteam/embedlog"
+)
+
+// Chi returns a chi middleware that logs requests using the matched route
+// pattern (e.g. "/users/{id}") instead of the raw path.
+func Chi(logger embedlog.Logger) func(http.Handler) http.Handler {
+	return New(logger, WithRoutePattern(func(r *http.Request) string {
+		if rc := chi.RouteContext(r.Context()); rc != nil {
+			if pattern := rc.RoutePattern(); pattern != "" {
+				return pattern
+			}
+		}
+		return r.URL.Path
+	}))
+}
@@ -0,0 +1,34 @@
+package httplog
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/embedlog"
+)
+
+// Echo returns an echo middleware that logs one access-log line per request
+// through logger and records RED metrics, using the matched route path. It
+// can't delegate to New like Chi does, since echo.HandlerFunc returns an
+// error New's http.Handler-based core has no way to observe; it instead
+// shares New's newRequestContext/logAccess helpers.
+func Echo(logger embedlog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			ctx, reqLogger, requestID := newRequestContext(r.Context(), logger, r)
+			c.Response().Header().Set(HeaderRequestID, requestID)
+			c.SetRequest(r.WithContext(ctx))
+
+			start := time.Now()
+			err := next(c)
+			duration := time.Since(start)
+
+			resp := c.Response()
+			logAccess(ctx, reqLogger, r, c.Path(), resp.Status, int(resp.Size), duration, err)
+
+			return err
+		}
+	}
+}
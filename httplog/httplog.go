@@ -0,0 +1,158 @@
+// Package httplog provides an HTTP access-log middleware built on
+// embedlog.Logger, with request-ID/trace-ID propagation and RED metrics.
+// Adapters for net/http, chi and echo are provided. Chi delegates to New
+// directly; echo's handler signature (echo.Context in, error out) doesn't
+// fit the http.Handler shape New wraps, so Echo instead calls the same
+// newRequestContext/logAccess helpers New is built on, to keep the request-ID
+// propagation, access-log fields and RED metrics identical across adapters.
+package httplog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vmkteam/embedlog"
+)
+
+// HeaderRequestID is the header used to read/propagate the request ID.
+const HeaderRequestID = "X-Request-ID"
+
+// HeaderTraceParent is the W3C trace context header.
+const HeaderTraceParent = "traceparent"
+
+type requestIDCtxKey struct{}
+
+// RequestIDFromContext returns the request ID attached to ctx, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	routePattern func(*http.Request) string
+}
+
+// WithRoutePattern sets a func returning the matched route template (e.g. "/users/{id}")
+// for a request, used instead of the raw path in logs and metrics labels.
+func WithRoutePattern(f func(*http.Request) string) Option {
+	return func(c *config) {
+		c.routePattern = f
+	}
+}
+
+// New returns a net/http middleware that logs one access-log line per request
+// through logger and records RED metrics.
+func New(logger embedlog.Logger, opts ...Option) func(http.Handler) http.Handler {
+	cfg := &config{routePattern: func(r *http.Request) string { return r.URL.Path }}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, reqLogger, requestID := newRequestContext(r.Context(), logger, r)
+			w.Header().Set(HeaderRequestID, requestID)
+			r = r.WithContext(ctx)
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			next.ServeHTTP(sw, r)
+			duration := time.Since(start)
+
+			logAccess(ctx, reqLogger, r, cfg.routePattern(r), sw.status, sw.size, duration, nil)
+		})
+	}
+}
+
+// newRequestContext attaches a request-ID (and, if present, a W3C trace-ID)
+// to ctx and to a child of logger, for use by every adapter in this package.
+func newRequestContext(ctx context.Context, logger embedlog.Logger, r *http.Request) (context.Context, embedlog.Logger, string) {
+	id := requestID(r)
+
+	ctx = context.WithValue(ctx, requestIDCtxKey{}, id)
+	reqLogger := logger.With(slog.String("requestID", id))
+	if traceID := traceIDFromTraceParent(r.Header.Get(HeaderTraceParent)); traceID != "" {
+		reqLogger = reqLogger.With(slog.String("traceID", traceID))
+	}
+	ctx = embedlog.NewContext(ctx, reqLogger)
+
+	return ctx, reqLogger, id
+}
+
+// logAccess records RED metrics and emits the one-line access log shared by
+// every adapter in this package. err is nil for the net/http and chi
+// adapters, which have no error return to report.
+func logAccess(ctx context.Context, logger embedlog.Logger, r *http.Request, route string, status, size int, duration time.Duration, err error) {
+	requestsTotal.WithLabelValues(r.Method, route, statusClass(status)).Inc()
+	requestDuration.WithLabelValues(r.Method, route).Observe(duration.Seconds())
+
+	logger.PrintOrErr(ctx, "HTTP request", err,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"route", route,
+		"status", status,
+		"size", size,
+		"duration", duration,
+		"remoteAddr", r.RemoteAddr,
+		"userAgent", r.UserAgent(),
+	)
+}
+
+// statusWriter wraps http.ResponseWriter to capture the status code and bytes written.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+// requestID returns the incoming X-Request-ID header, generating one if absent.
+func requestID(r *http.Request) string {
+	if id := r.Header.Get(HeaderRequestID); id != "" {
+		return id
+	}
+
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// traceIDFromTraceParent extracts the trace-id field from a W3C traceparent header.
+func traceIDFromTraceParent(traceParent string) string {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 {
+		return ""
+	}
+	return parts[1]
+}
+
+func statusClass(status int) string {
+	switch {
+	case status >= 500:
+		return "5xx"
+	case status >= 400:
+		return "4xx"
+	case status >= 300:
+		return "3xx"
+	default:
+		return "2xx"
+	}
+}
@@ -0,0 +1,100 @@
+package httplog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/embedlog"
+)
+
+// recordingHandler is a slog.Handler that keeps the last record handled, so
+// tests can assert on what Echo/New actually logged.
+type recordingHandler struct {
+	level slog.Level
+	msg   string
+	attrs []slog.Attr
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.level, h.msg, h.attrs = r.Level, r.Message, nil
+	r.Attrs(func(a slog.Attr) bool {
+		h.attrs = append(h.attrs, a)
+		return true
+	})
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func fieldValue(attrs []slog.Attr, key string) any {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value.Any()
+		}
+	}
+	return nil
+}
+
+func TestEchoLogsHandlerError(t *testing.T) {
+	rec := &recordingHandler{}
+	e := echo.New()
+	e.Use(Echo(embedlog.NewHandlerLogger(rec)))
+	wantErr := errors.New("boom")
+	e.GET("/users/:id", func(c echo.Context) error { return wantErr })
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if rec.level != slog.LevelError {
+		t.Errorf("level = %v, want %v", rec.level, slog.LevelError)
+	}
+	if got := fieldValue(rec.attrs, "err"); got != wantErr {
+		t.Errorf("logged err = %v, want %v", got, wantErr)
+	}
+}
+
+func TestEchoUsesMatchedRoute(t *testing.T) {
+	rec := &recordingHandler{}
+	e := echo.New()
+	e.Use(Echo(embedlog.NewHandlerLogger(rec)))
+	e.GET("/users/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got, want := fieldValue(rec.attrs, "route"), "/users/:id"; got != want {
+		t.Errorf("route = %v, want %v", got, want)
+	}
+}
+
+func TestNewAndEchoLogTheSameFieldSet(t *testing.T) {
+	httpRec, echoRec := &recordingHandler{}, &recordingHandler{}
+
+	httpHandler := New(embedlog.NewHandlerLogger(httpRec))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	httpHandler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	e := echo.New()
+	e.Use(Echo(embedlog.NewHandlerLogger(echoRec)))
+	e.GET("/ping", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	wantKeys := []string{"method", "path", "route", "status", "size", "duration", "remoteAddr", "userAgent"}
+	for _, k := range wantKeys {
+		if fieldValue(httpRec.attrs, k) == nil {
+			t.Errorf("New(): missing field %q", k)
+		}
+		if fieldValue(echoRec.attrs, k) == nil {
+			t.Errorf("Echo(): missing field %q", k)
+		}
+	}
+}
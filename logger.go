@@ -0,0 +1,11 @@
+package embedlog
+
+import "log/slog"
+
+// NewHandlerLogger returns a Logger backed directly by h, for sink
+// constructors (NewFileLogger, NewSyslogLogger, ...) and callers that need a
+// Logger over a custom slog.Handler (e.g. a recording handler in tests)
+// instead of going through NewLogger/NewDevLogger.
+func NewHandlerLogger(h slog.Handler) Logger {
+	return Logger{slog: slog.New(h)}
+}
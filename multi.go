@@ -0,0 +1,56 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// multiHandler fans every record out to a list of handlers.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// MultiHandler returns an slog.Handler that forwards every record to each of hs,
+// so a service can log to e.g. stdout, syslog and a file at once without
+// rewriting call sites.
+func MultiHandler(hs ...slog.Handler) slog.Handler {
+	return &multiHandler{handlers: hs}
+}
+
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var err error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if hErr := handler.Handle(ctx, record.Clone()); hErr != nil && err == nil {
+			err = hErr
+		}
+	}
+	return err
+}
+
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &multiHandler{handlers: handlers}
+}
@@ -0,0 +1,214 @@
+// Package pglog provides a ready-to-use go-pg QueryHook that logs queries
+// through embedlog.Logger, with slow-query detection, sampled full-query
+// logging, zenrpc namespace enrichment, parameter redaction and Prometheus
+// metrics.
+package pglog
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/semrush/zenrpc/v2"
+	"github.com/vmkteam/embedlog"
+)
+
+const stashKeyStartedAt = "pglog:startedAt"
+
+// queryTotal and queryDuration are package-level singletons: a service that
+// calls NewQueryHook more than once (sharding, read replicas, ...) must be
+// able to register Collectors() exactly once without hitting a
+// duplicate-descriptor panic. Hooks are told apart by the "db" label instead.
+var (
+	queryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pg_query_total",
+		Help: "Total number of go-pg queries.",
+	}, []string{"db", "operation", "rpc"})
+
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pg_query_duration_seconds",
+		Help:    "Duration of go-pg queries in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"db", "operation", "rpc"})
+)
+
+// Collectors returns the package's Prometheus collectors, for registration.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{queryTotal, queryDuration}
+}
+
+// QueryHook is a go-pg BeforeQuery/AfterQuery hook that logs queries via
+// embedlog.Logger and reports Prometheus metrics.
+type QueryHook struct {
+	embedlog.Logger
+
+	name          string
+	slowThreshold time.Duration
+	sampleRate    uint32
+	redact        *regexp.Regexp
+	rpcMethodFunc RPCMethodFunc
+
+	sampleCounter atomic.Uint32
+}
+
+// RPCMethodFunc extracts the current zenrpc method name from ctx, to be
+// joined with the zenrpc namespace for the "rpc" metric/log label. zenrpc
+// only exposes the namespace via context (zenrpc.NamespaceFromContext); the
+// method name is passed directly to handlers, not stashed in ctx, so callers
+// that want it labeled must supply their own extractor (e.g. by stashing it
+// in ctx themselves before calling the database).
+type RPCMethodFunc func(ctx context.Context) string
+
+// Option configures a QueryHook.
+type Option func(*QueryHook)
+
+// WithName labels this hook's metrics with name (e.g. the connection or
+// shard name), so multiple hooks in one service don't collapse into one
+// Prometheus series. Defaults to "default".
+func WithName(name string) Option {
+	return func(h *QueryHook) {
+		h.name = name
+	}
+}
+
+// WithSlowThreshold logs queries slower than d at WARN instead of DEBUG.
+func WithSlowThreshold(d time.Duration) Option {
+	return func(h *QueryHook) {
+		h.slowThreshold = d
+	}
+}
+
+// WithSampleRate logs the full formatted query for one in n queries,
+// even when the query is fast. n<=1 logs every query.
+func WithSampleRate(n uint32) Option {
+	return func(h *QueryHook) {
+		h.sampleRate = n
+	}
+}
+
+// WithRedact masks query parameters matching re with "***".
+func WithRedact(re *regexp.Regexp) Option {
+	return func(h *QueryHook) {
+		h.redact = re
+	}
+}
+
+// WithRPCMethodFunc sets the function used to extract the current zenrpc
+// method name for the "rpc" label, to be joined with the zenrpc namespace.
+// If unset, the "rpc" label is just the namespace.
+func WithRPCMethodFunc(fn RPCMethodFunc) Option {
+	return func(h *QueryHook) {
+		h.rpcMethodFunc = fn
+	}
+}
+
+// NewQueryHook returns a go-pg QueryHook that logs through logger.
+func NewQueryHook(logger embedlog.Logger, opts ...Option) *QueryHook {
+	h := &QueryHook{
+		Logger:        logger,
+		name:          "default",
+		slowThreshold: 200 * time.Millisecond,
+		sampleRate:    1,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// BeforeQuery implements pg.QueryHook.
+func (h *QueryHook) BeforeQuery(ctx context.Context, event *pg.QueryEvent) (context.Context, error) {
+	if event.Stash == nil {
+		event.Stash = make(map[interface{}]interface{})
+	}
+	event.Stash[stashKeyStartedAt] = time.Now()
+
+	return ctx, nil
+}
+
+// AfterQuery implements pg.QueryHook.
+func (h *QueryHook) AfterQuery(ctx context.Context, event *pg.QueryEvent) error {
+	var since time.Duration
+	if v, ok := event.Stash[stashKeyStartedAt]; ok {
+		if startedAt, ok := v.(time.Time); ok {
+			since = time.Since(startedAt)
+		}
+	}
+
+	query, err := event.FormattedQuery()
+	if err != nil {
+		h.Error(ctx, "failed to format query", "err", err)
+		return nil
+	}
+
+	method := h.rpcMethod(ctx)
+	operation := queryOperation(string(query))
+
+	queryTotal.WithLabelValues(h.name, operation, method).Inc()
+	queryDuration.WithLabelValues(h.name, operation, method).Observe(since.Seconds())
+
+	switch {
+	case event.Err != nil:
+		h.Error(ctx, h.redactQuery(string(query)), "err", event.Err, "rpc", method, "duration", since)
+	case since >= h.slowThreshold:
+		h.Log().WarnContext(ctx, h.redactQuery(string(query)), "rpc", method, "duration", since)
+	case h.shouldSample():
+		h.Log().DebugContext(ctx, h.redactQuery(string(query)), "rpc", method, "duration", since)
+	default:
+		h.Log().DebugContext(ctx, "query finished", "operation", operation, "rpc", method, "duration", since)
+	}
+
+	return nil
+}
+
+func (h *QueryHook) shouldSample() bool {
+	if h.sampleRate <= 1 {
+		return true
+	}
+	return h.sampleCounter.Add(1)%h.sampleRate == 0
+}
+
+func (h *QueryHook) redactQuery(query string) string {
+	if h.redact == nil {
+		return query
+	}
+	return h.redact.ReplaceAllString(query, "***")
+}
+
+// rpcMethod returns the "namespace.method" zenrpc identifier for ctx, if any.
+// The method half is only available when h.rpcMethodFunc was configured via
+// WithRPCMethodFunc, since zenrpc itself only exposes the namespace via ctx.
+func (h *QueryHook) rpcMethod(ctx context.Context) string {
+	ns := zenrpc.NamespaceFromContext(ctx)
+
+	var method string
+	if h.rpcMethodFunc != nil {
+		method = h.rpcMethodFunc(ctx)
+	}
+
+	switch {
+	case ns == "" && method == "":
+		return ""
+	case method == "":
+		return ns
+	case ns == "":
+		return method
+	default:
+		return ns + "." + method
+	}
+}
+
+// queryOperation returns the SQL verb (SELECT, INSERT, ...) the query starts with.
+func queryOperation(query string) string {
+	query = strings.TrimSpace(query)
+	if i := strings.IndexByte(query, ' '); i >= 0 {
+		query = query[:i]
+	}
+	return strings.ToUpper(query)
+}
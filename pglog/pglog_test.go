@@ -0,0 +1,84 @@
+package pglog
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/vmkteam/embedlog"
+)
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"select * from users", "SELECT"},
+		{"  INSERT INTO users VALUES (1)", "INSERT"},
+		{"update users set name = 'a'", "UPDATE"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := queryOperation(tt.query); got != tt.want {
+			t.Errorf("queryOperation(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryHookRedactQuery(t *testing.T) {
+	h := NewQueryHook(embedlog.Logger{}, WithRedact(regexp.MustCompile(`password='[^']*'`)))
+
+	got := h.redactQuery(`update users set password='secret' where id=1`)
+	want := `update users set *** where id=1`
+	if got != want {
+		t.Errorf("redactQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestQueryHookRedactQueryNoRule(t *testing.T) {
+	h := NewQueryHook(embedlog.Logger{})
+
+	query := `select 1`
+	if got := h.redactQuery(query); got != query {
+		t.Errorf("redactQuery() = %q, want %q", got, query)
+	}
+}
+
+func TestQueryHookShouldSample(t *testing.T) {
+	h := NewQueryHook(embedlog.Logger{}, WithSampleRate(3))
+
+	var hits int
+	for i := 0; i < 9; i++ {
+		if h.shouldSample() {
+			hits++
+		}
+	}
+	if hits != 3 {
+		t.Errorf("shouldSample() hit count = %d, want 3", hits)
+	}
+}
+
+func TestQueryHookShouldSampleDefault(t *testing.T) {
+	h := NewQueryHook(embedlog.Logger{})
+
+	for i := 0; i < 5; i++ {
+		if !h.shouldSample() {
+			t.Fatalf("shouldSample() = false at call %d, want true with default rate", i)
+		}
+	}
+}
+
+func TestQueryHookRPCMethod(t *testing.T) {
+	ctx := context.Background()
+
+	h := NewQueryHook(embedlog.Logger{})
+	if got := h.rpcMethod(ctx); got != "" {
+		t.Errorf("rpcMethod() = %q, want empty without a namespace or WithRPCMethodFunc", got)
+	}
+
+	h = NewQueryHook(embedlog.Logger{}, WithRPCMethodFunc(func(context.Context) string { return "GetUser" }))
+	if got, want := h.rpcMethod(ctx), "GetUser"; got != want {
+		t.Errorf("rpcMethod() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strconv"
+)
+
+// DefaultMask is used by Redacted and NewRedactingHandler when no mask is configured.
+const DefaultMask = "REDACTED"
+
+// Redacted wraps a value so it always logs as DefaultMask; the wrapped value
+// itself stays available through the Value field.
+type Redacted[T any] struct {
+	Value T
+}
+
+// LogValue implements slog.LogValuer.
+func (Redacted[T]) LogValue() slog.Value {
+	return slog.StringValue(DefaultMask)
+}
+
+// String implements fmt.Stringer so Redacted doesn't leak its value via %v/%s either.
+func (Redacted[T]) String() string {
+	return DefaultMask
+}
+
+// RedactRule masks attribute values by key name (KeyPattern) and/or by value
+// shape (ValuePattern or ValueMatch), replacing them with Mask (DefaultMask if empty).
+type RedactRule struct {
+	KeyPattern   *regexp.Regexp
+	ValuePattern *regexp.Regexp
+	ValueMatch   func(value string) bool
+	Mask         string
+}
+
+func (r RedactRule) matchesValue(value string) bool {
+	if r.ValuePattern != nil && r.ValuePattern.MatchString(value) {
+		return true
+	}
+	if r.ValueMatch != nil && r.ValueMatch(value) {
+		return true
+	}
+	return false
+}
+
+func (r RedactRule) mask() string {
+	if r.Mask != "" {
+		return r.Mask
+	}
+	return DefaultMask
+}
+
+// DefaultRedactRules masks common secret-shaped keys (password, token,
+// authorization, cookie, api key, secret) found anywhere in an attribute group.
+func DefaultRedactRules() []RedactRule {
+	return []RedactRule{
+		{KeyPattern: regexp.MustCompile(`(?i)password|token|authorization|cookie|api[_-]?key|secret`)},
+	}
+}
+
+// jwtPattern matches JWT-shaped strings (three base64url segments).
+var jwtPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
+
+// WithJWTValues masks attribute values that look like a JWT, regardless of key.
+func WithJWTValues() RedactRule {
+	return RedactRule{ValuePattern: jwtPattern}
+}
+
+// cardPattern matches 13-23 characters of digits, spaces or dashes that start
+// and end with a digit; isLuhnValid then requires 13-19 digits among them.
+var cardPattern = regexp.MustCompile(`^[\d][\d\- ]{11,21}[\d]$`)
+
+// WithCardNumberValues masks attribute values that are a Luhn-valid card number,
+// regardless of key.
+func WithCardNumberValues() RedactRule {
+	return RedactRule{ValueMatch: isLuhnValid}
+}
+
+// isLuhnValid reports whether a digit string (optionally spaced or dashed)
+// passes the Luhn checksum used by credit card numbers.
+func isLuhnValid(digits string) bool {
+	if !cardPattern.MatchString(digits) {
+		return false
+	}
+
+	sum, alt, count := 0, false, 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		c := digits[i]
+		if c == '-' || c == ' ' {
+			continue
+		}
+		n, err := strconv.Atoi(string(c))
+		if err != nil {
+			return false
+		}
+		if alt {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		alt = !alt
+		count++
+	}
+	if count < 13 || count > 19 {
+		return false
+	}
+	return sum%10 == 0
+}
+
+// redactingHandler is an slog.Handler that masks attribute values matching rules.
+type redactingHandler struct {
+	next  slog.Handler
+	rules []RedactRule
+}
+
+// NewRedactingHandler wraps next so that attribute values matching rules are
+// replaced with their mask before being handled. With no rules given,
+// DefaultRedactRules is used.
+func NewRedactingHandler(next slog.Handler, rules ...RedactRule) slog.Handler {
+	if len(rules) == 0 {
+		rules = DefaultRedactRules()
+	}
+	return &redactingHandler{next: next, rules: rules}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	newRecord := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		newRecord.AddAttrs(h.redactAttr(a))
+		return true
+	})
+
+	return h.next.Handle(ctx, newRecord)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(a)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(redacted), rules: h.rules}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name), rules: h.rules}
+}
+
+func (h *redactingHandler) redactAttr(a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = h.redactAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	}
+
+	for _, rule := range h.rules {
+		if rule.KeyPattern != nil && rule.KeyPattern.MatchString(a.Key) {
+			return slog.String(a.Key, rule.mask())
+		}
+		if rule.matchesValue(a.Value.String()) {
+			return slog.String(a.Key, rule.mask())
+		}
+	}
+
+	return a
+}
@@ -0,0 +1,175 @@
+package embedlog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Facility is an RFC 5424 syslog facility.
+type Facility int
+
+// Standard syslog facilities used by applications.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+	FacilityLocal0 Facility = 16
+	FacilityLocal1 Facility = 17
+	FacilityLocal2 Facility = 18
+	FacilityLocal3 Facility = 19
+	FacilityLocal4 Facility = 20
+	FacilityLocal5 Facility = 21
+	FacilityLocal6 Facility = 22
+	FacilityLocal7 Facility = 23
+)
+
+// SyslogOption configures NewSyslogLogger.
+type SyslogOption func(*syslogHandler)
+
+// WithSyslogNetwork dials addr over network ("udp", "tcp" or "unix") instead
+// of the default "udp" to "localhost:514".
+func WithSyslogNetwork(network, addr string) SyslogOption {
+	return func(h *syslogHandler) {
+		h.network, h.addr = network, addr
+	}
+}
+
+// syslogHandler writes records as RFC 5424 syslog messages.
+//
+// attrs added via WithAttrs have the group active at that time baked into
+// their key immediately, so later WithGroup calls can't retroactively
+// reparent them; group only prefixes attrs attached directly to a record.
+type syslogHandler struct {
+	mu       *sync.Mutex
+	conn     net.Conn
+	network  string
+	addr     string
+	tag      string
+	facility Facility
+	hostname string
+	attrs    []slog.Attr
+	group    string
+}
+
+// NewSyslogLogger returns a Logger that writes RFC 5424 syslog messages
+// tagged tag to facility, over UDP to localhost:514 by default.
+func NewSyslogLogger(tag string, facility Facility, opts ...SyslogOption) (Logger, error) {
+	h := &syslogHandler{
+		mu:       new(sync.Mutex),
+		network:  "udp",
+		addr:     "localhost:514",
+		tag:      tag,
+		facility: facility,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	h.hostname = hostname
+
+	conn, err := net.Dial(h.network, h.addr)
+	if err != nil {
+		return Logger{}, fmt.Errorf("dial syslog: %w", err)
+	}
+	h.conn = conn
+
+	return NewHandlerLogger(h), nil
+}
+
+func severity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3
+	case level >= slog.LevelWarn:
+		return 4
+	case level >= slog.LevelInfo:
+		return 6
+	default:
+		return 7
+	}
+}
+
+func (h *syslogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *syslogHandler) Handle(_ context.Context, record slog.Record) error {
+	pri := int(h.facility)*8 + severity(record.Level)
+	timestamp := record.Time.UTC().Format(time.RFC3339)
+
+	var sb strings.Builder
+	sb.WriteString(escapeSyslog(record.Message))
+	for _, a := range h.attrs {
+		// Already prefixed with the group active when WithAttrs added it.
+		writeAttr(&sb, "", a)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		writeAttr(&sb, h.group, a)
+		return true
+	})
+
+	msg := fmt.Sprintf("<%d>1 %s %s %s %d - - %s\n", pri, timestamp, h.hostname, h.tag, os.Getpid(), sb.String())
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.conn.Write([]byte(msg))
+	return err
+}
+
+func writeAttr(sb *strings.Builder, group string, a slog.Attr) {
+	key := a.Key
+	if group != "" {
+		key = group + "." + key
+	}
+	sb.WriteByte(' ')
+	sb.WriteString(key)
+	sb.WriteByte('=')
+	sb.WriteString(escapeSyslog(a.Value.String()))
+}
+
+// escapeSyslog replaces control characters that could otherwise split a
+// single record into multiple forged syslog lines.
+func escapeSyslog(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	baked := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		baked[i] = prefixAttr(h.group, a)
+	}
+
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), baked...)
+	return &clone
+}
+
+// prefixAttr prepends group to a's key, if group is set.
+func prefixAttr(group string, a slog.Attr) slog.Attr {
+	if group == "" {
+		return a
+	}
+	return slog.Attr{Key: group + "." + a.Key, Value: a.Value}
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	if clone.group != "" {
+		clone.group += "." + name
+	} else {
+		clone.group = name
+	}
+	return &clone
+}
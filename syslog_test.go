@@ -0,0 +1,98 @@
+package embedlog
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewSyslogLoggerWritesRFC5424(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() = %v", err)
+	}
+	defer listener.Close()
+
+	logger, err := NewSyslogLogger("myapp", FacilityLocal0, WithSyslogNetwork("udp", listener.LocalAddr().String()))
+	if err != nil {
+		t.Fatalf("NewSyslogLogger() = %v", err)
+	}
+
+	msgCh := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 4096)
+		n, _, _ := listener.ReadFrom(buf)
+		msgCh <- string(buf[:n])
+	}()
+
+	logger.Print(context.Background(), "hello")
+
+	msg := <-msgCh
+	if !strings.Contains(msg, "myapp") || !strings.Contains(msg, "hello") {
+		t.Errorf("syslog message = %q, want it to contain the tag and message", msg)
+	}
+}
+
+func TestSyslogHandlerGroupScoping(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	h := &syslogHandler{
+		mu:       new(sync.Mutex),
+		conn:     client,
+		tag:      "test",
+		facility: FacilityUser,
+		hostname: "host",
+	}
+	logger := slog.New(h).WithGroup("g1").With("a", 1).WithGroup("g2")
+
+	msgCh := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 4096)
+		n, _ := server.Read(buf)
+		msgCh <- string(buf[:n])
+	}()
+
+	logger.Info("hello")
+
+	msg := <-msgCh
+	if !strings.Contains(msg, "g1.a=1") {
+		t.Errorf("expected the attr added before WithGroup(%q) to stay scoped under g1, got: %s", "g2", msg)
+	}
+	if strings.Contains(msg, "g1.g2.a=1") {
+		t.Errorf("attr was retroactively reparented under the later group: %s", msg)
+	}
+}
+
+func TestSyslogHandlerAppliesCurrentGroupToRecordAttrs(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	h := &syslogHandler{
+		mu:       new(sync.Mutex),
+		conn:     client,
+		tag:      "test",
+		facility: FacilityUser,
+		hostname: "host",
+	}
+	logger := slog.New(h).WithGroup("g1")
+
+	msgCh := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 4096)
+		n, _ := server.Read(buf)
+		msgCh <- string(buf[:n])
+	}()
+
+	logger.Info("hello", "b", 2)
+
+	msg := <-msgCh
+	if !strings.Contains(msg, "g1.b=2") {
+		t.Errorf("expected a record attr to be scoped under the active group, got: %s", msg)
+	}
+}